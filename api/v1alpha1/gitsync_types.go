@@ -21,9 +21,15 @@ import (
 	"sort"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// GitSyncReconcileRequestedAtAnnotation, when set on a GitSync, instructs the controller
+// to force a re-sync without waiting for Interval; its value is copied to
+// GitSyncStatus.LastHandledReconcileAt once handled.
+const GitSyncReconcileRequestedAtAnnotation = "reconcile.numaplane.io/requestedAt"
+
 // +kubebuilder:validation:Enum="";Pending;Running;Failed;NotApplicable
 type GitSyncPhase string
 
@@ -38,33 +44,162 @@ const (
 	// GitSyncConditionConfigured has the status True when the GitSync
 	// has valid configuration.
 	GitSyncConditionConfigured ConditionType = "Configured"
+
+	// GitSyncConditionReady is the summary condition: it has the status True
+	// only when all other conditions are in their "happy" state. This is the
+	// condition that `kubectl wait --for=condition=Ready` should key off of.
+	GitSyncConditionReady ConditionType = "Ready"
+
+	// GitSyncConditionReconciling has the status True while the controller is
+	// actively working towards reconciling the desired state (e.g. cloning,
+	// fetching, or applying manifests).
+	GitSyncConditionReconciling ConditionType = "Reconciling"
+
+	// GitSyncConditionStalled has the status True when the controller has
+	// stopped making progress towards reconciling the desired state and the
+	// condition typically requires user action to resolve (e.g. repeated
+	// auth/clone failures).
+	GitSyncConditionStalled ConditionType = "Stalled"
+
+	// GitSyncReasonSuspended is the Reason set on the Ready condition when
+	// Spec.Suspend is true and reconciliation has been short-circuited.
+	GitSyncReasonSuspended = "Suspended"
+
+	// GitSyncConditionRolledBack has the status True when the controller has pinned
+	// sync to Spec.RollbackTo instead of the latest revision.
+	GitSyncConditionRolledBack ConditionType = "RolledBack"
+
+	// DefaultMaxHistoryLen is the default cap on the number of entries kept in
+	// GitSyncStatus.History, used when the controller isn't configured with an override.
+	DefaultMaxHistoryLen = 10
 )
 
 // GitSyncSpec defines the desired state of GitSync
+// +kubebuilder:validation:XValidation:rule="!has(self.repositoryPaths) || self.repositoryPaths.all(p, self.repositoryPaths.exists_one(q, q.name == p.name))",message="RepositoryPath names must be unique"
+// +kubebuilder:validation:XValidation:rule="size(self.destinations) > 0 || has(self.destination)",message="at least one Destination must be specified"
+// +kubebuilder:validation:XValidation:rule="!has(self.interval) || self.interval >= duration('1s')",message="interval must be at least 1s"
+// +kubebuilder:validation:XValidation:rule="!has(self.timeout) || !has(self.interval) || self.timeout <= self.interval",message="timeout must not exceed interval"
 type GitSyncSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	// RepositoryPath lists the Git Repository path to watch
-	RepositoryPath RepositoryPath `json:"repositoryPath"`
+	// Deprecated: use RepositoryPaths instead. If both are set, RepositoryPaths takes precedence.
+	// +optional
+	RepositoryPath *RepositoryPath `json:"repositoryPath,omitempty"`
+
+	// RepositoryPaths lists the Git Repository paths to watch
+	// +optional
+	RepositoryPaths []RepositoryPath `json:"repositoryPaths,omitempty"`
 
 	// Destination describe which cluster/namespace to sync it
-	Destination Destination `json:"destination"`
+	// Deprecated: use Destinations instead. If both are set, Destinations takes precedence.
+	// +optional
+	Destination *Destination `json:"destination,omitempty"`
+
+	// Destinations describe which clusters/namespaces to sync it to
+	// +optional
+	Destinations []Destination `json:"destinations,omitempty"`
+
+	// Suspend tells the controller to suspend reconciliation of this GitSync.
+	// When true, the controller short-circuits and sets the Ready condition's Reason to
+	// "Suspended" without performing any Git operations.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Interval is the desired reconciliation cadence, e.g. to poll the Git repository for changes.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// Timeout for Git operations such as clone/fetch. Must not exceed Interval.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// RollbackTo, when set to a commit hash present in Status.History, instructs the
+	// controller to pin sync to that revision instead of the latest TargetRevision and
+	// surface a RolledBack condition.
+	// +optional
+	RollbackTo *string `json:"rollbackTo,omitempty"`
 }
 
 // GitSyncStatus defines the observed state of GitSync
 type GitSyncStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
+
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	Phase GitSyncPhase `json:"phase,omitempty"`
 	// Conditions are the latest available observations of a resource's current state.
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// Message is added if there's a failure
 	Message string `json:"message,omitempty"`
 
 	// Last commit processed and the status
+	// Deprecated: use CommitStatuses instead. Unlike RepositoryPath/Destination, there is no
+	// automatic fallback between this field and CommitStatuses: the controller populates
+	// whichever one(s) it has implemented against, and callers should read CommitStatuses.
+	// +optional
 	CommitStatus *CommitStatus `json:"commitStatus,omitempty"`
+
+	// CommitStatuses reports the sync status of each RepositoryPath independently, keyed by
+	// RepositoryPath.Name
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	CommitStatuses []RepoCommitStatus `json:"commitStatuses,omitempty"`
+
+	// Artifact represents the last successfully fetched and verified source,
+	// decoupled from whether it was successfully applied (see CommitStatus for that)
+	// +optional
+	Artifact *Artifact `json:"artifact,omitempty"`
+
+	// LastHandledReconcileAt holds the value of the most recent
+	// reconcile.numaplane.io/requestedAt annotation that was handled by the controller,
+	// used to detect manually requested reconciliations.
+	// +optional
+	LastHandledReconcileAt string `json:"lastHandledReconcileAt,omitempty"`
+
+	// History holds the last N commits that were attempted, newest first, capped at
+	// DefaultMaxHistoryLen (or the controller-configured override). Useful for
+	// diagnosing flapping syncs and for implementing rollback.
+	// +optional
+	History []CommitStatus `json:"history,omitempty"`
+
+	// LastSuccessfulCommit is a convenience pointer to the most recent CommitStatus pushed
+	// with Synced true. It is kept even after that entry has been trimmed out of History.
+	// +optional
+	LastSuccessfulCommit *CommitStatus `json:"lastSuccessfulCommit,omitempty"`
+}
+
+// Artifact represents the output of a successful Git fetch, modeled after
+// source-controller's Artifact: a stable, verifiable pointer to "what was fetched"
+type Artifact struct {
+	// Revision is the resolved commit SHA for the TargetRevision, even when a
+	// branch or tag was requested
+	Revision string `json:"revision"`
+
+	// Checksum is a checksum of the rendered manifest tree
+	Checksum string `json:"checksum"`
+
+	// Path is the path that was actually applied, relative to the repository root
+	Path string `json:"path"`
+
+	// Size is the size in bytes of the fetched artifact
+	// +optional
+	Size int64 `json:"size,omitempty"`
+
+	// LastUpdateTime is the timestamp of when this Artifact was last updated
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 // RepositoryPath indicates a particular Git path
@@ -84,6 +219,13 @@ type RepositoryPath struct {
 	// TargetRevision specifies the target revision to sync to, it can be a branch, a tag,
 	// or a commit hash.
 	TargetRevision string `json:"targetRevision"`
+
+	// SecretRef references a Secret in the same namespace containing credentials for this
+	// repository. Supported keys are "username"/"password" for basic auth, "bearerToken",
+	// or "identity"/"identity.pub"/"known_hosts" for SSH, and an optional CA bundle under
+	// "caFile".
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
 }
 
 // Destination indicates a Cluster to sync to
@@ -110,8 +252,18 @@ type CommitStatus struct {
 	Error string `json:"error,omitempty"`
 }
 
+// RepoCommitStatus maintains the CommitStatus for a single RepositoryPath, identified by name
+type RepoCommitStatus struct {
+	// Name is the RepositoryPath.Name that this status applies to
+	Name string `json:"name"`
+
+	CommitStatus `json:",inline"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Revision",type=string,JSONPath=`.status.artifact.revision`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // GitSync is the Schema for the gitsyncs API
 type GitSync struct {
@@ -127,6 +279,13 @@ func (gitSync GitSync) String() string {
 	return gitSync.Namespace + "/" + gitSync.Name
 }
 
+// ReconcileRequestedAt returns the value of the reconcile.numaplane.io/requestedAt
+// annotation, or empty string if it isn't set. Reconcilers compare this against
+// Status.LastHandledReconcileAt to detect a manually requested reconciliation.
+func (gitSync GitSync) ReconcileRequestedAt() string {
+	return gitSync.Annotations[GitSyncReconcileRequestedAtAnnotation]
+}
+
 //+kubebuilder:object:root=true
 
 // GitSyncList contains a list of GitSync
@@ -140,16 +299,47 @@ func init() {
 	SchemeBuilder.Register(&GitSync{}, &GitSyncList{})
 }
 
-// ContainsClusterDestination determines if the cluster matches the Destination
+// GetRepositoryPaths returns the full list of RepositoryPaths, honoring the deprecated
+// singular RepositoryPath field when RepositoryPaths is not set
+func (gitSyncSpec *GitSyncSpec) GetRepositoryPaths() []RepositoryPath {
+	if len(gitSyncSpec.RepositoryPaths) > 0 {
+		return gitSyncSpec.RepositoryPaths
+	}
+	if gitSyncSpec.RepositoryPath != nil {
+		return []RepositoryPath{*gitSyncSpec.RepositoryPath}
+	}
+	return nil
+}
+
+// GetDestinations returns the full list of Destinations, honoring the deprecated
+// singular Destination field when Destinations is not set
+func (gitSyncSpec *GitSyncSpec) GetDestinations() []Destination {
+	if len(gitSyncSpec.Destinations) > 0 {
+		return gitSyncSpec.Destinations
+	}
+	if gitSyncSpec.Destination != nil {
+		return []Destination{*gitSyncSpec.Destination}
+	}
+	return nil
+}
+
+// ContainsClusterDestination determines if the cluster matches any of the Destinations
 func (gitSyncSpec *GitSyncSpec) ContainsClusterDestination(cluster string) bool {
-	return gitSyncSpec.Destination.Cluster == cluster
+	for _, d := range gitSyncSpec.GetDestinations() {
+		if d.Cluster == cluster {
+			return true
+		}
+	}
+	return false
 }
 
-// GetDestinationNamespace gets the namespace with the given cluster,
+// GetDestinationNamespace gets the namespace of the Destination matching the given cluster,
 // if not found, then return empty.
 func (gitSyncSpec *GitSyncSpec) GetDestinationNamespace(cluster string) string {
-	if gitSyncSpec.Destination.Cluster == cluster {
-		return gitSyncSpec.Destination.Namespace
+	for _, d := range gitSyncSpec.GetDestinations() {
+		if d.Cluster == cluster {
+			return d.Namespace
+		}
 	}
 	return ""
 }
@@ -172,7 +362,9 @@ func (status *GitSyncStatus) InitializeConditions(conditionTypes ...ConditionTyp
 }
 
 // setCondition sets a Condition, and sorts the list of Conditions
+// (+listType=map, +listMapKey=type: at most one Condition per Type is kept)
 func (status *GitSyncStatus) setCondition(condition metav1.Condition) {
+	condition.ObservedGeneration = status.ObservedGeneration
 	var conditions []metav1.Condition
 	// copy the list of Conditions, and if we find one of this type, replace it and return
 	for _, c := range status.Conditions {
@@ -192,6 +384,43 @@ func (status *GitSyncStatus) setCondition(condition metav1.Condition) {
 	status.Conditions = conditions
 }
 
+// IsStatusStale returns true if this status was last observed at a generation older than gen,
+// meaning it does not yet reflect the current spec
+func (status *GitSyncStatus) IsStatusStale(gen int64) bool {
+	return status.ObservedGeneration < gen
+}
+
+// PushCommitStatus prepends cs to History (newest first) and trims it down to max entries,
+// centralizing the bounded-deque semantics. It also updates LastSuccessfulCommit when cs.Synced.
+func (status *GitSyncStatus) PushCommitStatus(cs CommitStatus, max int) {
+	if max < 0 {
+		max = 0
+	}
+	status.History = append([]CommitStatus{cs}, status.History...)
+	if len(status.History) > max {
+		status.History = status.History[:max]
+	}
+	if cs.Synced {
+		status.LastSuccessfulCommit = &cs
+	}
+}
+
+// MarkRolledBack sets the RolledBack condition to true, for use when the controller has
+// pinned sync to Spec.RollbackTo instead of the latest revision
+func (status *GitSyncStatus) MarkRolledBack(reason, message string) {
+	status.markTypeStatus(GitSyncConditionRolledBack, metav1.ConditionTrue, reason, message)
+}
+
+// GetCondition returns the Condition of the given type, or nil if it's not set
+func (status *GitSyncStatus) GetCondition(t ConditionType) *metav1.Condition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == string(t) {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 // InitConditions sets conditions to Unknown state.
 func (status *GitSyncStatus) InitConditions() {
 	status.InitializeConditions(GitSyncConditionConfigured)
@@ -222,20 +451,91 @@ func (status *GitSyncStatus) MarkConditionUnknown(t ConditionType, reason, messa
 	status.markTypeStatus(t, metav1.ConditionUnknown, reason, message)
 }
 
-// MarkRunning sets the GitSync to Running
+// MarkRunning sets the GitSync to Running, a completed sync: Ready is true and
+// Reconciling is cleared, since no work is in flight
 func (status *GitSyncStatus) MarkRunning() {
 	status.MarkConditionTrue(GitSyncConditionConfigured)
 	status.SetPhase(GitSyncPhaseRunning, "")
+	status.MarkReady()
 }
 
 // MarkFailed sets the GitSync to Failed
 func (status *GitSyncStatus) MarkFailed(reason, message string) {
 	status.MarkConditionFalse(GitSyncConditionConfigured, reason, message)
 	status.SetPhase(GitSyncPhaseFailed, message)
+	status.MarkConditionFalse(GitSyncConditionReady, reason, message)
+	status.markTypeStatus(GitSyncConditionReconciling, metav1.ConditionFalse, reason, message)
 }
 
 // MarkNotApplicable sets the GitSync to Not Applicable
 func (status *GitSyncStatus) MarkNotApplicable(reason, message string) {
 	status.MarkConditionFalse(GitSyncConditionConfigured, reason, message)
 	status.SetPhase(GitSyncPhaseNA, message)
+	status.MarkConditionFalse(GitSyncConditionReady, reason, message)
+	status.markTypeStatus(GitSyncConditionReconciling, metav1.ConditionFalse, reason, message)
+}
+
+// MarkReconciling sets the Reconciling condition to true, indicating the
+// controller is actively working towards the desired state
+func (status *GitSyncStatus) MarkReconciling(reason, message string) {
+	status.markTypeStatus(GitSyncConditionReconciling, metav1.ConditionTrue, reason, message)
+}
+
+// MarkStalled sets the Stalled condition to true and clears Ready, indicating
+// that progress has halted and user action is typically required
+func (status *GitSyncStatus) MarkStalled(reason, message string) {
+	status.markTypeStatus(GitSyncConditionStalled, metav1.ConditionTrue, reason, message)
+	status.markTypeStatus(GitSyncConditionReconciling, metav1.ConditionFalse, reason, message)
+	status.MarkConditionFalse(GitSyncConditionReady, reason, message)
+}
+
+// MarkReady sets the summary Ready condition to true and clears Stalled and Reconciling,
+// since Ready only applies once a sync has actually completed
+func (status *GitSyncStatus) MarkReady() {
+	status.markTypeStatus(GitSyncConditionStalled, metav1.ConditionFalse, "Successful", "Successful")
+	status.markTypeStatus(GitSyncConditionReconciling, metav1.ConditionFalse, "Successful", "Successful")
+	status.MarkConditionTrue(GitSyncConditionReady)
+}
+
+// MarkSuspended sets the Ready condition to false with reason Suspended, for use when
+// Spec.Suspend is true and reconciliation has been short-circuited
+func (status *GitSyncStatus) MarkSuspended() {
+	status.MarkConditionFalse(GitSyncConditionReady, GitSyncReasonSuspended, "GitSync is suspended")
+}
+
+// GetCommitStatus returns the RepoCommitStatus for the given RepositoryPath name, or nil if not found
+func (status *GitSyncStatus) GetCommitStatus(name string) *RepoCommitStatus {
+	for i := range status.CommitStatuses {
+		if status.CommitStatuses[i].Name == name {
+			return &status.CommitStatuses[i]
+		}
+	}
+	return nil
+}
+
+// SetCommitStatus sets the RepoCommitStatus for the given RepositoryPath name, replacing any
+// existing entry
+func (status *GitSyncStatus) SetCommitStatus(cs RepoCommitStatus) {
+	for i := range status.CommitStatuses {
+		if status.CommitStatuses[i].Name == cs.Name {
+			status.CommitStatuses[i] = cs
+			return
+		}
+	}
+	status.CommitStatuses = append(status.CommitStatuses, cs)
+}
+
+// GetArtifact returns the last known-good Artifact, or nil if none has been set
+func (status *GitSyncStatus) GetArtifact() *Artifact {
+	return status.Artifact
+}
+
+// SetArtifact records a as the last known-good Artifact
+func (status *GitSyncStatus) SetArtifact(a Artifact) {
+	status.Artifact = &a
+}
+
+// HasArtifact returns true if an Artifact has been recorded
+func (status *GitSyncStatus) HasArtifact() bool {
+	return status.Artifact != nil
 }